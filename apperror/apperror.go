@@ -0,0 +1,58 @@
+// Package apperror mendefinisikan tipe error yang dipahami oleh HTTPErrorHandler
+// di main.go, sehingga setiap error yang dikembalikan handler berakhir sebagai
+// envelope JSON yang konsisten.
+package apperror
+
+import "net/http"
+
+// FieldError merepresentasikan satu kegagalan validasi pada satu field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// AppError adalah error yang sudah membawa informasi HTTP status, code
+// machine-readable, dan pesan yang aman ditampilkan ke client.
+type AppError struct {
+	Status  int
+	Code    string
+	Message string
+	Details []FieldError
+}
+
+func (e *AppError) Error() string {
+	return e.Message
+}
+
+// New membuat AppError dengan status dan code tertentu.
+func New(status int, code, message string) *AppError {
+	return &AppError{Status: status, Code: code, Message: message}
+}
+
+// NotFound membungkus pesan sebagai error 404.
+func NotFound(message string) *AppError {
+	return New(http.StatusNotFound, "not_found", message)
+}
+
+// BadRequest membungkus pesan sebagai error 400.
+func BadRequest(message string) *AppError {
+	return New(http.StatusBadRequest, "bad_request", message)
+}
+
+// Internal membungkus pesan sebagai error 500. Jangan sertakan detail error
+// database mentah di message karena ini dikirim langsung ke client.
+func Internal(message string) *AppError {
+	return New(http.StatusInternalServerError, "internal_error", message)
+}
+
+// Validation membungkus kegagalan validasi struct sebagai error 422 dengan
+// rincian per-field di Details.
+func Validation(details []FieldError) *AppError {
+	return &AppError{
+		Status:  http.StatusUnprocessableEntity,
+		Code:    "validation_error",
+		Message: "Validation failed",
+		Details: details,
+	}
+}
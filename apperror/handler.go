@@ -0,0 +1,76 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// errorEnvelope adalah bentuk JSON untuk response error, selalu sama
+// terlepas dari jenis error-nya.
+type errorEnvelope struct {
+	Status  string       `json:"status"`
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// HTTPErrorHandler menerjemahkan error apa pun yang dikembalikan handler
+// menjadi envelope JSON yang konsisten. Dipasang sebagai e.HTTPErrorHandler
+// di main.go sehingga handler cukup `return apperror.NotFound(...)` dkk.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	var appErr *AppError
+	var httpErr *echo.HTTPError
+
+	var status int
+	var code string
+	var message string
+	var details []FieldError
+
+	switch {
+	case errors.As(err, &appErr):
+		status, code, message, details = appErr.Status, appErr.Code, appErr.Message, appErr.Details
+	case errors.As(err, &httpErr):
+		status = httpErr.Code
+		code = statusCode(status)
+		message = http.StatusText(status)
+		if msg, ok := httpErr.Message.(string); ok {
+			message = msg
+		}
+	default:
+		status = http.StatusInternalServerError
+		code = "internal_error"
+		message = http.StatusText(http.StatusInternalServerError)
+	}
+
+	if jsonErr := c.JSON(status, errorEnvelope{
+		Status:  "error",
+		Code:    code,
+		Message: message,
+		Details: details,
+	}); jsonErr != nil {
+		c.Logger().Error(jsonErr)
+	}
+}
+
+// statusCode menurunkan code machine-readable dari status HTTP untuk error
+// yang bukan AppError (mis. echo.HTTPError bawaan framework).
+func statusCode(status int) string {
+	switch status {
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusBadRequest:
+		return "bad_request"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	default:
+		return "internal_error"
+	}
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Jenis token yang didukung, disimpan di claim "type" supaya refresh token
+// tidak bisa dipakai sebagai access token ataupun sebaliknya.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// ErrInvalidTokenType dikembalikan saat token yang valid secara signature
+// dipakai untuk tujuan yang berbeda dari claim "type"-nya.
+var ErrInvalidTokenType = errors.New("invalid token type")
+
+// Claims adalah claim kustom yang disisipkan ke JWT, di atas claim standar JWT.
+type Claims struct {
+	UserID int    `json:"user_id"`
+	Type   string `json:"type"`
+	jwt.RegisteredClaims
+}
+
+// GenerateToken menandatangani JWT HS256 untuk userID dengan tipe dan masa berlaku tertentu.
+func GenerateToken(userID int, tokenType string, secret []byte, ttl time.Duration) (string, error) {
+	claims := Claims{
+		UserID: userID,
+		Type:   tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(secret)
+}
+
+// ParseToken memverifikasi signature dan memastikan tokenString punya tipe wantType.
+func ParseToken(tokenString string, secret []byte, wantType string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(t *jwt.Token) (any, error) {
+		return secret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+
+	if claims.Type != wantType {
+		return nil, ErrInvalidTokenType
+	}
+
+	return claims, nil
+}
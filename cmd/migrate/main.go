@@ -0,0 +1,91 @@
+// Command migrate menjalankan operasi schema migration (up/down/version/force)
+// terhadap database yang dikonfigurasi lewat environment variables, memakai
+// DSN yang sama dengan yang dipakai aplikasi utama.
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"todolist/database"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+)
+
+const migrationsPath = "file://migrations"
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: Could not find .env file, using environment variables from OS")
+	}
+
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	db, err := sql.Open("postgres", database.Dsn())
+	if err != nil {
+		log.Fatalf("Could not connect to the database: %v", err)
+	}
+	defer db.Close()
+
+	// "up" pakai database.Migrate langsung, sama seperti AUTO_MIGRATE di
+	// InitDb, supaya hanya ada satu tempat yang merakit driver + source
+	// migrasi. Subcommand lain butuh akses ke *migrate.Migrate itu sendiri
+	// (Down/Version/Force), jadi tetap dirakit di sini.
+	if os.Args[1] == "up" {
+		if err := database.Migrate(db); err != nil {
+			log.Fatalf("migrate up failed: %v", err)
+		}
+		return
+	}
+
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		log.Fatalf("Could not create migrate driver: %v", err)
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(migrationsPath, "postgres", driver)
+	if err != nil {
+		log.Fatalf("Could not initialize migrate: %v", err)
+	}
+
+	switch os.Args[1] {
+	case "down":
+		if err := m.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			log.Fatalf("migrate down failed: %v", err)
+		}
+	case "version":
+		version, dirty, err := m.Version()
+		if err != nil {
+			log.Fatalf("migrate version failed: %v", err)
+		}
+		fmt.Printf("version=%d dirty=%t\n", version, dirty)
+	case "force":
+		if len(os.Args) < 3 {
+			log.Fatal("migrate force requires a version argument")
+		}
+		version, err := strconv.Atoi(os.Args[2])
+		if err != nil {
+			log.Fatalf("invalid version %q: %v", os.Args[2], err)
+		}
+		if err := m.Force(version); err != nil {
+			log.Fatalf("migrate force failed: %v", err)
+		}
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate <up|down|version|force <version>>")
+	os.Exit(1)
+}
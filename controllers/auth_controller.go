@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"todolist/apperror"
+	"todolist/auth"
+	"todolist/middleware"
+	"todolist/models"
+	"todolist/repository"
+	"todolist/response"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
+)
+
+// uniqueViolation adalah kode error Postgres untuk pelanggaran unique constraint.
+const uniqueViolation = "23505"
+
+// AuthController menampung handler untuk registrasi, login, refresh token dan profil user.
+type AuthController struct {
+	users      *repository.UserRepository
+	jwtSecret  []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// NewAuthController membuat AuthController baru. jwtSecret dipakai untuk
+// menandatangani access dan refresh token.
+func NewAuthController(users *repository.UserRepository, jwtSecret []byte, accessTTL, refreshTTL time.Duration) *AuthController {
+	return &AuthController{
+		users:      users,
+		jwtSecret:  jwtSecret,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+	}
+}
+
+// tokenPair adalah bentuk JSON untuk pasangan access/refresh token yang dikembalikan
+// oleh Register, Login dan Refresh.
+type tokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Register menangani POST /auth/register.
+func (ac *AuthController) Register(c echo.Context) error {
+	var payload models.RegisterPayload
+	if err := c.Bind(&payload); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+	if err := c.Validate(&payload); err != nil {
+		return err
+	}
+
+	passwordHash, err := auth.HashPassword(payload.Password)
+	if err != nil {
+		middleware.Logger(c).Error("failed to hash password", "error", err)
+		return apperror.Internal("Failed to register user")
+	}
+
+	user := models.User{Email: payload.Email, PasswordHash: passwordHash}
+	if err := ac.users.Create(&user); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return apperror.New(http.StatusConflict, "email_taken", "Email is already registered")
+		}
+		middleware.Logger(c).Error("failed to create user", "error", err)
+		return apperror.Internal("Failed to register user")
+	}
+
+	tokens, err := ac.issueTokens(user.Id)
+	if err != nil {
+		middleware.Logger(c).Error("failed to issue tokens", "error", err)
+		return apperror.Internal("Failed to register user")
+	}
+
+	return response.Success(c, http.StatusCreated, tokens, "User registered successfully")
+}
+
+// Login menangani POST /auth/login.
+func (ac *AuthController) Login(c echo.Context) error {
+	var payload models.LoginPayload
+	if err := c.Bind(&payload); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+	if err := c.Validate(&payload); err != nil {
+		return err
+	}
+
+	user, err := ac.users.GetByEmail(payload.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.New(http.StatusUnauthorized, "invalid_credentials", "Invalid email or password")
+		}
+		middleware.Logger(c).Error("failed to fetch user", "error", err)
+		return apperror.Internal("Failed to log in")
+	}
+
+	if !auth.CheckPassword(user.PasswordHash, payload.Password) {
+		return apperror.New(http.StatusUnauthorized, "invalid_credentials", "Invalid email or password")
+	}
+
+	tokens, err := ac.issueTokens(user.Id)
+	if err != nil {
+		middleware.Logger(c).Error("failed to issue tokens", "error", err)
+		return apperror.Internal("Failed to log in")
+	}
+
+	return response.Success(c, http.StatusOK, tokens, "Logged in successfully")
+}
+
+// Refresh menangani POST /auth/refresh, menukar refresh token yang masih valid
+// dengan pasangan access/refresh token baru.
+func (ac *AuthController) Refresh(c echo.Context) error {
+	var payload models.RefreshPayload
+	if err := c.Bind(&payload); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+	if err := c.Validate(&payload); err != nil {
+		return err
+	}
+
+	claims, err := auth.ParseToken(payload.RefreshToken, ac.jwtSecret, auth.TokenTypeRefresh)
+	if err != nil {
+		return apperror.New(http.StatusUnauthorized, "invalid_token", "Invalid or expired refresh token")
+	}
+
+	tokens, err := ac.issueTokens(claims.UserID)
+	if err != nil {
+		middleware.Logger(c).Error("failed to issue tokens", "error", err)
+		return apperror.Internal("Failed to refresh token")
+	}
+
+	return response.Success(c, http.StatusOK, tokens, "Token refreshed successfully")
+}
+
+// Me menangani GET /me, mengembalikan profil user yang sedang login.
+func (ac *AuthController) Me(c echo.Context) error {
+	userId, err := middleware.UserID(c)
+	if err != nil {
+		return apperror.New(http.StatusUnauthorized, "unauthorized", "Authentication required")
+	}
+
+	user, err := ac.users.GetByID(userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFound("User not found")
+		}
+		middleware.Logger(c).Error("failed to fetch user", "error", err)
+		return apperror.Internal("Failed to fetch user")
+	}
+
+	return response.Success(c, http.StatusOK, user, "User fetched successfully")
+}
+
+// issueTokens membuat pasangan access/refresh token baru untuk userId.
+func (ac *AuthController) issueTokens(userId int) (tokenPair, error) {
+	accessToken, err := auth.GenerateToken(userId, auth.TokenTypeAccess, ac.jwtSecret, ac.accessTTL)
+	if err != nil {
+		return tokenPair{}, err
+	}
+
+	refreshToken, err := auth.GenerateToken(userId, auth.TokenTypeRefresh, ac.jwtSecret, ac.refreshTTL)
+	if err != nil {
+		return tokenPair{}, err
+	}
+
+	return tokenPair{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// readyTimeout membatasi berapa lama /readyz menunggu db.PingContext sebelum menyerah.
+const readyTimeout = 2 * time.Second
+
+// HealthController menampung handler untuk liveness/readiness probe ala Kubernetes.
+type HealthController struct {
+	db *sql.DB
+}
+
+// NewHealthController membuat HealthController baru di atas koneksi db yang diberikan.
+func NewHealthController(db *sql.DB) *HealthController {
+	return &HealthController{db: db}
+}
+
+// Healthz menandakan proses masih hidup. Tidak menyentuh dependency apa pun.
+func (hc *HealthController) Healthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Readyz menandakan service siap menerima trafik, yaitu koneksi ke database masih hidup.
+func (hc *HealthController) Readyz(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), readyTimeout)
+	defer cancel()
+
+	if err := hc.db.PingContext(ctx); err != nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"status": "not ready"})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ready"})
+}
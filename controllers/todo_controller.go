@@ -0,0 +1,231 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"todolist/apperror"
+	"todolist/middleware"
+	"todolist/models"
+	"todolist/repository"
+	"todolist/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// TodoController menampung handler Echo untuk resource todos. Repository
+// di-inject lewat konstruktor supaya handler bisa diuji dengan fake repository.
+type TodoController struct {
+	repo *repository.TodoRepository
+}
+
+// NewTodoController membuat TodoController baru di atas repo yang diberikan.
+func NewTodoController(repo *repository.TodoRepository) *TodoController {
+	return &TodoController{repo: repo}
+}
+
+// parseTodoID memvalidasi :id sebagai integer sebelum dipakai di query SQL,
+// supaya id non-numerik (mis. "abc") berakhir sebagai 400 yang rapi alih-alih
+// error "invalid input syntax for type integer" mentah dari Postgres.
+func parseTodoID(c echo.Context) (string, error) {
+	id := c.Param("id")
+	if _, err := strconv.Atoi(id); err != nil {
+		return "", apperror.BadRequest("Invalid todo id")
+	}
+	return id, nil
+}
+
+// Create menangani POST /todos.
+func (tc *TodoController) Create(c echo.Context) error {
+	ownerId, err := middleware.UserID(c)
+	if err != nil {
+		return apperror.New(http.StatusUnauthorized, "unauthorized", "Authentication required")
+	}
+
+	var newTodo models.Todo
+
+	// Gunakan c.Bind() yang lebih idiomatik di Echo untuk parsing request body.
+	if err := c.Bind(&newTodo); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	if err := c.Validate(&newTodo); err != nil {
+		return err
+	}
+
+	if err := tc.repo.Create(&newTodo, ownerId); err != nil {
+		middleware.Logger(c).Error("failed to create todo", "error", err)
+		// Jangan kirim detail error database ke client.
+		return apperror.Internal("Failed to create todo")
+	}
+
+	// Gunakan status 201 Created untuk resource yang baru dibuat.
+	return response.Success(c, http.StatusCreated, newTodo, "Todo created successfully")
+}
+
+// List menangani GET /todos dengan dukungan filter, pencarian, pagination dan sorting.
+func (tc *TodoController) List(c echo.Context) error {
+	ownerId, err := middleware.UserID(c)
+	if err != nil {
+		return apperror.New(http.StatusUnauthorized, "unauthorized", "Authentication required")
+	}
+
+	opts := repository.ListOptions{
+		Search: c.QueryParam("q"),
+		Limit:  defaultLimit,
+		Sort:   "id",
+		Order:  "asc",
+	}
+
+	if raw := c.QueryParam("status"); raw != "" {
+		status, err := strconv.ParseBool(raw)
+		if err != nil {
+			return apperror.BadRequest("Invalid status filter")
+		}
+		opts.Status = &status
+	}
+
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return apperror.BadRequest("Invalid limit")
+		}
+		if limit > maxLimit {
+			limit = maxLimit
+		}
+		opts.Limit = limit
+	}
+
+	if raw := c.QueryParam("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return apperror.BadRequest("Invalid offset")
+		}
+		opts.Offset = offset
+	}
+
+	if raw := c.QueryParam("sort"); raw != "" {
+		switch raw {
+		case "id", "title", "status":
+			opts.Sort = raw
+		default:
+			return apperror.BadRequest("Invalid sort field")
+		}
+	}
+
+	if raw := c.QueryParam("order"); raw != "" {
+		switch raw {
+		case "asc", "desc":
+			opts.Order = raw
+		default:
+			return apperror.BadRequest("Invalid sort order")
+		}
+	}
+
+	todos, total, err := tc.repo.List(ownerId, opts)
+	if err != nil {
+		middleware.Logger(c).Error("failed to fetch todos", "error", err)
+		return apperror.Internal("Failed to fetch todos")
+	}
+
+	meta := map[string]any{
+		"total":  total,
+		"limit":  opts.Limit,
+		"offset": opts.Offset,
+	}
+	return response.SuccessWithMeta(c, http.StatusOK, todos, meta, "Todos fetched successfully")
+}
+
+// GetByID menangani GET /todos/:id.
+func (tc *TodoController) GetByID(c echo.Context) error {
+	ownerId, err := middleware.UserID(c)
+	if err != nil {
+		return apperror.New(http.StatusUnauthorized, "unauthorized", "Authentication required")
+	}
+
+	id, err := parseTodoID(c)
+	if err != nil {
+		return err
+	}
+
+	todo, err := tc.repo.GetByID(id, ownerId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFound("Todo not found")
+		}
+		middleware.Logger(c).Error("failed to fetch todo", "error", err)
+		return apperror.Internal("Failed to fetch todo")
+	}
+
+	return response.Success(c, http.StatusOK, todo, "Todo fetched successfully")
+}
+
+// Update menangani PATCH /todos/:id.
+func (tc *TodoController) Update(c echo.Context) error {
+	ownerId, err := middleware.UserID(c)
+	if err != nil {
+		return apperror.New(http.StatusUnauthorized, "unauthorized", "Authentication required")
+	}
+
+	id, err := parseTodoID(c)
+	if err != nil {
+		return err
+	}
+	var payload models.UpdateTodoPayload
+
+	if err := c.Bind(&payload); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	if err := c.Validate(&payload); err != nil {
+		return err
+	}
+
+	updated, err := tc.repo.Patch(id, ownerId, payload)
+	if err != nil {
+		if errors.Is(err, repository.ErrNoFields) {
+			return apperror.BadRequest("No fields to update")
+		}
+		// Cek jika errornya karena todo tidak ditemukan
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.NotFound("Todo not found")
+		}
+		middleware.Logger(c).Error("failed to update todo", "error", err)
+		return apperror.Internal("Failed to update todo")
+	}
+
+	return response.Success(c, http.StatusOK, updated, "Todo updated successfully")
+}
+
+// Delete menangani DELETE /todos/:id.
+func (tc *TodoController) Delete(c echo.Context) error {
+	ownerId, err := middleware.UserID(c)
+	if err != nil {
+		return apperror.New(http.StatusUnauthorized, "unauthorized", "Authentication required")
+	}
+
+	id, err := parseTodoID(c)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := tc.repo.Delete(id, ownerId)
+	if err != nil {
+		middleware.Logger(c).Error("failed to delete todo", "error", err)
+		return apperror.Internal("Failed to delete todo")
+	}
+
+	if !deleted {
+		return apperror.NotFound("Todo not found")
+	}
+
+	// Status 200 OK dengan pesan, atau bisa juga 204 No Content tanpa body.
+	return response.Success(c, http.StatusOK, nil, "Todo deleted successfully")
+}
@@ -18,24 +18,25 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 )
 
-func InitDb() *sql.DB {
-	// Memuat variabel dari file .env
-	// Tanda "_" digunakan jika kita tidak butuh menangani error secara spesifik,
-	// tapi lebih baik menanganinya.
-	err := godotenv.Load()
-	if err != nil {
-		log.Println("Warning: Could not find .env file, using environment variables from OS")
-	}
+// migrationsPath menunjuk ke folder berisi file migrasi golang-migrate.
+const migrationsPath = "file://migrations"
 
-	// Membaca setiap variabel dari environment
+// Dsn membangun Data Source Name Postgres dari environment variables.
+func Dsn() string {
 	dbUser := os.Getenv("DB_USER")
 	dbPassword := os.Getenv("DB_PASSWORD")
 	dbHost := os.Getenv("DB_HOST")
@@ -43,8 +44,7 @@ func InitDb() *sql.DB {
 	dbName := os.Getenv("DB_NAME")
 	dbSSLMode := os.Getenv("DB_SSLMODE")
 
-	// Membangun Data Source Name (DSN) dari environment variables
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
 		dbUser,
 		dbPassword,
 		dbHost,
@@ -52,13 +52,82 @@ func InitDb() *sql.DB {
 		dbName,
 		dbSSLMode,
 	)
+}
+
+func InitDb() *sql.DB {
+	// Memuat variabel dari file .env
+	// Tanda "_" digunakan jika kita tidak butuh menangani error secara spesifik,
+	// tapi lebih baik menanganinya.
+	err := godotenv.Load()
+	if err != nil {
+		log.Println("Warning: Could not find .env file, using environment variables from OS")
+	}
 
 	// sql.Open tetap sama
-	sqlDB, err := sql.Open("postgres", dsn)
+	sqlDB, err := sql.Open("postgres", Dsn())
 	if err != nil {
 		// Sebaiknya gunakan log.Fatal agar lebih informatif daripada panic()
 		log.Fatalf("Could not connect to the database: %v", err)
 	}
 
+	if os.Getenv("AUTO_MIGRATE") == "true" {
+		if err := Migrate(sqlDB); err != nil {
+			log.Fatalf("Could not apply migrations: %v", err)
+		}
+	}
+
+	configurePool(sqlDB)
+
 	return sqlDB
 }
+
+// configurePool menerapkan pengaturan connection pool dari environment
+// variables, sehingga ukuran pool bisa disesuaikan per-environment tanpa
+// perubahan kode. Setiap variabel bersifat opsional; default *sql.DB dipakai
+// jika tidak diset.
+func configurePool(db *sql.DB) {
+	if raw := os.Getenv("DB_MAX_OPEN_CONNS"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil {
+			db.SetMaxOpenConns(n)
+		} else {
+			log.Printf("Warning: invalid DB_MAX_OPEN_CONNS %q, ignoring", raw)
+		}
+	}
+
+	if raw := os.Getenv("DB_MAX_IDLE_CONNS"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil {
+			db.SetMaxIdleConns(n)
+		} else {
+			log.Printf("Warning: invalid DB_MAX_IDLE_CONNS %q, ignoring", raw)
+		}
+	}
+
+	if raw := os.Getenv("DB_CONN_MAX_LIFETIME"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil {
+			db.SetConnMaxLifetime(d)
+		} else {
+			log.Printf("Warning: invalid DB_CONN_MAX_LIFETIME %q, ignoring", raw)
+		}
+	}
+}
+
+// Migrate menjalankan seluruh migrasi yang belum diterapkan terhadap db.
+// Dipanggil dari InitDb saat AUTO_MIGRATE=true, dan dari cmd/migrate untuk
+// operasi manual (up/down/version/force).
+func Migrate(db *sql.DB) error {
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		return fmt.Errorf("could not create migrate driver: %w", err)
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(migrationsPath, "postgres", driver)
+	if err != nil {
+		return fmt.Errorf("could not initialize migrate: %w", err)
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("could not apply migrations: %w", err)
+	}
+
+	return nil
+}
@@ -1,32 +1,37 @@
 package main
 
 import (
-	"database/sql"
-	"fmt"
+	"context"
+	"errors"
 	"log"
 	"net/http"
-	"strings"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"todolist/apperror"
+	"todolist/controllers"
 	"todolist/database"
+	appmiddleware "todolist/middleware"
+	"todolist/repository"
+	"todolist/routes"
+	"todolist/validation"
 
 	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
 )
 
-// Todo struct tetap sama, merepresentasikan data di database.
-type Todo struct {
-	Id          int    `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      bool   `json:"status"`
-}
+// shutdownTimeout adalah batas waktu default untuk menunggu request yang
+// sedang berjalan selesai sebelum server dipaksa berhenti.
+const shutdownTimeout = 10 * time.Second
 
-// UpdateTodoPayload digunakan khusus untuk binding data saat PATCH/update.
-// Menggunakan pointer (*) memungkinkan kita membedakan antara nilai yang tidak dikirim
-// (nil) dengan nilai default (misalnya string kosong "" atau boolean false).
-type UpdateTodoPayload struct {
-	Title       *string `json:"title"`
-	Description *string `json:"description"`
-	Status      *bool   `json:"status"`
-}
+// Default masa berlaku access dan refresh token jika JWT_ACCESS_TTL /
+// JWT_REFRESH_TTL tidak diset.
+const (
+	defaultAccessTTL  = 15 * time.Minute
+	defaultRefreshTTL = 7 * 24 * time.Hour
+)
 
 func main() {
 	db := database.InitDb()
@@ -38,156 +43,79 @@ func main() {
 		log.Fatalf("Database connection failed: %v", err)
 	}
 
-	e := echo.New()
-
-	// --- CREATE ---
-	e.POST("/todos", func(c echo.Context) error {
-		var newTodo Todo
-
-		// Gunakan c.Bind() yang lebih idiomatik di Echo untuk parsing request body.
-		if err := c.Bind(&newTodo); err != nil {
-			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
-		}
-
-		// Validasi sederhana
-		if newTodo.Title == "" {
-			return c.JSON(http.StatusBadRequest, map[string]string{"message": "Title cannot be empty"})
-		}
-
-		err := db.QueryRow(
-			"INSERT INTO todos (title, description, status) VALUES ($1, $2, $3) RETURNING id",
-			newTodo.Title, newTodo.Description, newTodo.Status,
-		).Scan(&newTodo.Id)
-
-		if err != nil {
-			log.Printf("Error creating todo: %v", err) // Log error di server
-			// Jangan kirim detail error database ke client.
-			return c.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to create todo"})
-		}
-
-		// Gunakan status 201 Created untuk resource yang baru dibuat.
-		return c.JSON(http.StatusCreated, map[string]any{
-			"status":  "success",
-			"data":    newTodo,
-			"message": "Todo created successfully",
-		})
-	})
-
-	// --- READ ALL ---
-	e.GET("/todos", func(c echo.Context) error {
-		rows, err := db.Query("SELECT id, title, description, status FROM todos ORDER BY id ASC")
-		if err != nil {
-			log.Printf("Error fetching todos: %v", err)
-			return c.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to fetch todos"})
-		}
-		defer rows.Close()
-
-		var todos []Todo
-		for rows.Next() {
-			var todo Todo
-			if err := rows.Scan(&todo.Id, &todo.Title, &todo.Description, &todo.Status); err != nil {
-				log.Printf("Error scanning todo row: %v", err)
-				return c.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to process data"})
-			}
-			todos = append(todos, todo)
-		}
-
-		return c.JSON(http.StatusOK, map[string]any{
-			"status":  "success",
-			"data":    todos,
-			"message": "Todos fetched successfully",
-		})
-	})
-
-	// --- UPDATE ---
-	e.PATCH("/todos/:id", func(c echo.Context) error {
-		id := c.Param("id")
-		var payload UpdateTodoPayload
-
-		if err := c.Bind(&payload); err != nil {
-			return c.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
-		}
+	jwtSecret := []byte(os.Getenv("JWT_SECRET"))
+	if len(jwtSecret) == 0 {
+		log.Fatal("JWT_SECRET environment variable must be set")
+	}
 
-		// Membangun query secara dinamis tapi dengan cara yang AMAN dari SQL Injection.
-		setParts := []string{}
-		args := []any{}
-		argId := 1
+	todoRepo := repository.NewTodoRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	todoController := controllers.NewTodoController(todoRepo)
+	healthController := controllers.NewHealthController(db)
+	authController := controllers.NewAuthController(userRepo, jwtSecret, envDuration("JWT_ACCESS_TTL", defaultAccessTTL), envDuration("JWT_REFRESH_TTL", defaultRefreshTTL))
 
-		if payload.Title != nil {
-			setParts = append(setParts, fmt.Sprintf("title = $%d", argId))
-			args = append(args, *payload.Title)
-			argId++
-		}
-		if payload.Description != nil {
-			setParts = append(setParts, fmt.Sprintf("description = $%d", argId))
-			args = append(args, *payload.Description)
-			argId++
-		}
-		if payload.Status != nil {
-			setParts = append(setParts, fmt.Sprintf("status = $%d", argId))
-			args = append(args, *payload.Status)
-			argId++
+	e := echo.New()
+	e.Validator = validation.New()
+	e.HTTPErrorHandler = apperror.HTTPErrorHandler
+	// Metrics harus jadi middleware terluar: ia membaca c.Response().Status
+	// setelah next(c) kembali, tapi untuk handler yang mengembalikan error
+	// biasa (apperror.*) response itu baru benar-benar ditulis belakangan,
+	// oleh RequestLogger atau oleh HTTPErrorHandler di ServeHTTP. Kalau
+	// Metrics didaftarkan di dalam RequestLogger/Recover, ia membaca status
+	// sebelum itu terjadi (selalu 200) dan tidak mencatat apa-apa untuk
+	// request yang panic.
+	e.Use(appmiddleware.Metrics())
+	e.Use(appmiddleware.RequestID())
+	e.Use(appmiddleware.RequestLogger())
+	e.Use(echomiddleware.Recover())
+	e.GET("/metrics", appmiddleware.Handler())
+
+	authMiddleware := appmiddleware.Auth(jwtSecret)
+	routes.RegisterTodoRoutes(e, todoController, authMiddleware)
+	routes.RegisterAuthRoutes(e, authController, authMiddleware)
+	routes.RegisterHealthRoutes(e, healthController)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		log.Println("Starting server on :8080")
+		if err := e.Start(":8080"); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("shutting down the server: %v", err)
 		}
+	}()
 
-		if len(setParts) == 0 {
-			return c.JSON(http.StatusBadRequest, map[string]string{"message": "No fields to update"})
-		}
+	// Tunggu sinyal shutdown, lalu beri waktu request yang sedang berjalan untuk selesai.
+	<-ctx.Done()
+	stop()
+	log.Println("Shutting down server...")
 
-		// Menambahkan `id` sebagai argumen terakhir
-		args = append(args, id)
-		query := fmt.Sprintf("UPDATE todos SET %s WHERE id = $%d RETURNING id, title, description, status",
-			strings.Join(setParts, ", "), argId)
-
-		var updatedTodo Todo
-		err := db.QueryRow(query, args...).Scan(&updatedTodo.Id, &updatedTodo.Title, &updatedTodo.Description, &updatedTodo.Status)
-
-		if err != nil {
-			// Cek jika errornya karena todo tidak ditemukan
-			if err == sql.ErrNoRows {
-				return c.JSON(http.StatusNotFound, map[string]string{"message": "Todo not found"})
-			}
-			log.Printf("Error updating todo: %v", err)
-			return c.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to update todo"})
-		}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGraceTimeout())
+	defer cancel()
 
-		return c.JSON(http.StatusOK, map[string]any{
-			"status":  "success",
-			"data":    updatedTodo, // Mengembalikan data yang sudah terupdate
-			"message": "Todo updated successfully",
-		})
-	})
-
-	// --- DELETE ---
-	e.DELETE("/todos/:id", func(c echo.Context) error {
-		id := c.Param("id")
-
-		result, err := db.Exec("DELETE FROM todos WHERE id = $1", id)
-		if err != nil {
-			log.Printf("Error deleting todo: %v", err)
-			return c.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to delete todo"})
-		}
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error during server shutdown: %v", err)
+	}
+}
 
-		// Cek apakah ada baris yang benar-benar terhapus.
-		rowsAffected, err := result.RowsAffected()
-		if err != nil {
-			log.Printf("Error checking rows affected: %v", err)
-			return c.JSON(http.StatusInternalServerError, map[string]string{"message": "An error occurred"})
-		}
+// shutdownGraceTimeout membaca SHUTDOWN_TIMEOUT dari environment (mis. "15s"),
+// jatuh kembali ke shutdownTimeout jika tidak diset atau tidak valid.
+func shutdownGraceTimeout() time.Duration {
+	return envDuration("SHUTDOWN_TIMEOUT", shutdownTimeout)
+}
 
-		if rowsAffected == 0 {
-			return c.JSON(http.StatusNotFound, map[string]string{"message": "Todo not found"})
-		}
+// envDuration membaca durasi dari environment variable key, jatuh kembali ke
+// fallback jika tidak diset atau tidak valid.
+func envDuration(key string, fallback time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
 
-		// Status 200 OK dengan pesan, atau bisa juga 204 No Content tanpa body.
-		return c.JSON(http.StatusOK, map[string]any{
-			"status":  "success",
-			"message": "Todo deleted successfully",
-		})
-	})
-
-	// Menangani error yang mungkin terjadi saat server dimulai (misal: port sudah dipakai).
-	log.Println("Starting server on :8080")
-	if err := e.Start(":8080"); err != nil && err != http.ErrServerClosed {
-		e.Logger.Fatal("shutting down the server")
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Printf("Warning: invalid %s %q, using default", key, raw)
+		return fallback
 	}
+	return d
 }
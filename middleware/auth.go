@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"errors"
+
+	"todolist/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+)
+
+// contextKeyUser adalah key yang dipakai echo-jwt untuk menaruh *jwt.Token di echo.Context.
+const contextKeyUser = "user"
+
+// Auth memverifikasi access token JWT di header Authorization dan menaruh
+// claims-nya ke context lewat contextKeyUser, siap diambil dengan UserID.
+func Auth(secret []byte) echo.MiddlewareFunc {
+	return echojwt.WithConfig(echojwt.Config{
+		ContextKey: contextKeyUser,
+		SigningKey: secret,
+		NewClaimsFunc: func(c echo.Context) jwt.Claims {
+			return new(auth.Claims)
+		},
+		ParseTokenFunc: func(c echo.Context, tokenString string) (any, error) {
+			claims, err := auth.ParseToken(tokenString, secret, auth.TokenTypeAccess)
+			if err != nil {
+				return nil, err
+			}
+			// echo-jwt menaruh apa pun yang dikembalikan di sini ke context apa
+			// adanya (lihat c.Set(config.ContextKey, token) di echojwt), jadi kita
+			// harus mengembalikan *jwt.Token, bukan *auth.Claims, supaya UserID
+			// (yang mengharapkan *jwt.Token) bisa membacanya kembali.
+			return &jwt.Token{Claims: claims, Valid: true}, nil
+		},
+	})
+}
+
+// errNoUserInContext menandakan Auth belum dipasang di route yang memanggil UserID.
+var errNoUserInContext = errors.New("no authenticated user in context")
+
+// UserID mengambil id user yang sudah diautentikasi Auth dari echo.Context.
+func UserID(c echo.Context) (int, error) {
+	raw := c.Get(contextKeyUser)
+	if raw == nil {
+		return 0, errNoUserInContext
+	}
+
+	token, ok := raw.(*jwt.Token)
+	if !ok {
+		return 0, errNoUserInContext
+	}
+
+	claims, ok := token.Claims.(*auth.Claims)
+	if !ok {
+		return 0, errNoUserInContext
+	}
+
+	return claims.UserID, nil
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests, labeled by route and status.",
+	}, []string{"route", "status"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds, labeled by route and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "status"})
+)
+
+// Metrics mencatat jumlah request dan latency per route/status ke Prometheus
+// default registry. Default Go collectors (GC, goroutines, dst.) terdaftar
+// otomatis lewat promauto, jadi tidak perlu registrasi manual.
+func Metrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+
+			route := c.Path()
+			status := strconv.Itoa(c.Response().Status)
+			requestsTotal.WithLabelValues(route, status).Inc()
+			requestDuration.WithLabelValues(route, status).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
+
+// Handler mengekspos /metrics dalam format yang dipahami Prometheus.
+func Handler() echo.HandlerFunc {
+	return echo.WrapHandler(promhttp.Handler())
+}
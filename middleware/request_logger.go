@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// loggerContextKey adalah tipe privat supaya context value tidak bentrok dengan paket lain.
+type loggerContextKey struct{}
+
+// RequestLogger mencatat setiap request sebagai satu baris JSON (method, path,
+// status, latency, bytes) lewat log/slog, dan menitipkan logger yang sudah
+// dibubuhi request_id ke context supaya handler bisa memakainya untuk log error.
+func RequestLogger() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			requestLogger := slog.Default().With("request_id", c.Response().Header().Get(echo.HeaderXRequestID))
+			c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), loggerContextKey{}, requestLogger)))
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			requestLogger.Info("request",
+				"method", c.Request().Method,
+				"path", c.Path(),
+				"status", c.Response().Status,
+				"latency_ms", time.Since(start).Milliseconds(),
+				"bytes", c.Response().Size,
+			)
+
+			return err
+		}
+	}
+}
+
+// RequestID adalah alias tipis ke middleware bawaan Echo supaya import-nya
+// terpusat di satu tempat bersama RequestLogger.
+func RequestID() echo.MiddlewareFunc {
+	return echomiddleware.RequestID()
+}
+
+// Logger mengambil logger yang sudah dibubuhi request_id dari context request.
+// Jatuh kembali ke slog.Default() jika RequestLogger belum dipasang (mis. di test).
+func Logger(c echo.Context) *slog.Logger {
+	if logger, ok := c.Request().Context().Value(loggerContextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,21 @@
+package models
+
+// Todo merepresentasikan satu baris data di tabel todos.
+type Todo struct {
+	Id          int    `json:"id"`
+	Title       string `json:"title" validate:"required,min=1,max=200"`
+	Description string `json:"description" validate:"max=2000"`
+	Status      bool   `json:"status"`
+}
+
+// UpdateTodoPayload digunakan khusus untuk binding data saat PATCH/update.
+// Menggunakan pointer (*) memungkinkan kita membedakan antara nilai yang tidak dikirim
+// (nil) dengan nilai default (misalnya string kosong "" atau boolean false). Tag
+// `omitnil` (bukan `omitempty`) membuat validasi skip hanya saat pointer-nya nil,
+// bukan saat nilai di baliknya kosong — `omitempty` men-dereference pointer dan
+// akan menganggap title:"" sama dengan "tidak dikirim", melewati `min=1`.
+type UpdateTodoPayload struct {
+	Title       *string `json:"title" validate:"omitnil,min=1,max=200"`
+	Description *string `json:"description" validate:"omitempty,max=2000"`
+	Status      *bool   `json:"status"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// User merepresentasikan satu baris data di tabel users. PasswordHash tidak
+// pernah diserialisasi ke JSON supaya tidak bocor lewat response API.
+type User struct {
+	Id           int       `json:"id"`
+	Email        string    `json:"email"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// RegisterPayload dipakai untuk binding body POST /auth/register.
+type RegisterPayload struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+// LoginPayload dipakai untuk binding body POST /auth/login.
+type LoginPayload struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// RefreshPayload dipakai untuk binding body POST /auth/refresh.
+type RefreshPayload struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
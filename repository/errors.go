@@ -0,0 +1,6 @@
+package repository
+
+import "errors"
+
+// ErrNoFields menandakan payload PATCH tidak membawa satu pun field untuk diupdate.
+var ErrNoFields = errors.New("no fields to update")
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"todolist/models"
+)
+
+// TodoRepository membungkus *sql.DB dan menjadi satu-satunya tempat yang
+// tahu soal query SQL untuk resource todos. Controller tidak pernah
+// menyentuh *sql.DB secara langsung. Setiap method menerima ownerId dan
+// menyertakannya di WHERE supaya satu user tidak pernah bisa menyentuh
+// todos milik user lain.
+type TodoRepository struct {
+	db *sql.DB
+}
+
+// NewTodoRepository membuat TodoRepository baru di atas koneksi db yang diberikan.
+func NewTodoRepository(db *sql.DB) *TodoRepository {
+	return &TodoRepository{db: db}
+}
+
+// Create menyimpan todo baru milik ownerId dan mengisi Id-nya dari hasil RETURNING.
+func (r *TodoRepository) Create(todo *models.Todo, ownerId int) error {
+	return r.db.QueryRow(
+		"INSERT INTO todos (title, description, status, owner_id) VALUES ($1, $2, $3, $4) RETURNING id",
+		todo.Title, todo.Description, todo.Status, ownerId,
+	).Scan(&todo.Id)
+}
+
+// sortColumns adalah allowlist kolom yang boleh dipakai di ORDER BY, supaya
+// ?sort= dari client tidak pernah langsung disisipkan ke query SQL.
+var sortColumns = map[string]string{
+	"id":     "id",
+	"title":  "title",
+	"status": "status",
+}
+
+// ListOptions menampung parameter filtering, pagination dan sorting untuk List.
+type ListOptions struct {
+	Status *bool
+	Search string
+	Limit  int
+	Offset int
+	Sort   string
+	Order  string
+}
+
+// GetByID mengambil satu todo milik ownerId berdasarkan id. Mengembalikan
+// sql.ErrNoRows jika tidak ditemukan atau bukan milik ownerId.
+func (r *TodoRepository) GetByID(id string, ownerId int) (*models.Todo, error) {
+	var todo models.Todo
+	err := r.db.QueryRow(
+		"SELECT id, title, description, status FROM todos WHERE id = $1 AND owner_id = $2", id, ownerId,
+	).Scan(&todo.Id, &todo.Title, &todo.Description, &todo.Status)
+	if err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+// List mengembalikan todos milik ownerId sesuai opts beserta total baris yang
+// cocok dengan filter (tanpa limit/offset) supaya client bisa melakukan pagination.
+func (r *TodoRepository) List(ownerId int, opts ListOptions) ([]models.Todo, int, error) {
+	whereParts := []string{"owner_id = $1"}
+	args := []any{ownerId}
+	argId := 2
+
+	if opts.Status != nil {
+		whereParts = append(whereParts, fmt.Sprintf("status = $%d", argId))
+		args = append(args, *opts.Status)
+		argId++
+	}
+	if opts.Search != "" {
+		whereParts = append(whereParts, fmt.Sprintf("(title ILIKE $%d OR description ILIKE $%d)", argId, argId))
+		args = append(args, "%"+opts.Search+"%")
+		argId++
+	}
+
+	where := "WHERE " + strings.Join(whereParts, " AND ")
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT count(*) FROM todos %s", where)
+	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// Sort/order sudah divalidasi controller terhadap allowlist, tapi kita
+	// jaga lagi di sini karena repository tidak boleh percaya input mentah.
+	sortColumn, ok := sortColumns[opts.Sort]
+	if !ok {
+		sortColumn = "id"
+	}
+	order := "ASC"
+	if strings.EqualFold(opts.Order, "desc") {
+		order = "DESC"
+	}
+
+	query := fmt.Sprintf(
+		"SELECT id, title, description, status FROM todos %s ORDER BY %s %s LIMIT $%d OFFSET $%d",
+		where, sortColumn, order, argId, argId+1,
+	)
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var todos []models.Todo
+	for rows.Next() {
+		var todo models.Todo
+		if err := rows.Scan(&todo.Id, &todo.Title, &todo.Description, &todo.Status); err != nil {
+			return nil, 0, err
+		}
+		todos = append(todos, todo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return todos, total, nil
+}
+
+// Patch membangun query UPDATE secara dinamis tapi dengan cara yang AMAN dari
+// SQL Injection, hanya menyertakan kolom yang benar-benar dikirim di payload,
+// dan dibatasi ke todo milik ownerId.
+func (r *TodoRepository) Patch(id string, ownerId int, payload models.UpdateTodoPayload) (*models.Todo, error) {
+	setParts := []string{}
+	args := []any{}
+	argId := 1
+
+	if payload.Title != nil {
+		setParts = append(setParts, fmt.Sprintf("title = $%d", argId))
+		args = append(args, *payload.Title)
+		argId++
+	}
+	if payload.Description != nil {
+		setParts = append(setParts, fmt.Sprintf("description = $%d", argId))
+		args = append(args, *payload.Description)
+		argId++
+	}
+	if payload.Status != nil {
+		setParts = append(setParts, fmt.Sprintf("status = $%d", argId))
+		args = append(args, *payload.Status)
+		argId++
+	}
+
+	if len(setParts) == 0 {
+		return nil, ErrNoFields
+	}
+
+	// Menambahkan `id` dan `owner_id` sebagai argumen terakhir
+	args = append(args, id, ownerId)
+	query := fmt.Sprintf("UPDATE todos SET %s WHERE id = $%d AND owner_id = $%d RETURNING id, title, description, status",
+		strings.Join(setParts, ", "), argId, argId+1)
+
+	var updated models.Todo
+	err := r.db.QueryRow(query, args...).Scan(&updated.Id, &updated.Title, &updated.Description, &updated.Status)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete menghapus todo milik ownerId berdasarkan id dan melaporkan apakah ada
+// baris yang benar-benar terhapus.
+func (r *TodoRepository) Delete(id string, ownerId int) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM todos WHERE id = $1 AND owner_id = $2", id, ownerId)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"todolist/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestTodoRepository_OwnerScoping memverifikasi bahwa GetByID, Patch dan
+// Delete selalu menyertakan owner_id di WHERE, sehingga satu user tidak
+// pernah bisa menyentuh todo milik user lain lewat repository ini.
+func TestTodoRepository_OwnerScoping(t *testing.T) {
+	const (
+		todoID      = "1"
+		otherUserID = 2
+	)
+
+	t.Run("GetByID returns ErrNoRows for another owner", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("failed to open sqlmock: %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT id, title, description, status FROM todos WHERE id = \\$1 AND owner_id = \\$2").
+			WithArgs(todoID, otherUserID).
+			WillReturnError(sql.ErrNoRows)
+
+		repo := NewTodoRepository(db)
+		if _, err := repo.GetByID(todoID, otherUserID); err != sql.ErrNoRows {
+			t.Fatalf("expected sql.ErrNoRows, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("Patch returns ErrNoRows for another owner", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("failed to open sqlmock: %v", err)
+		}
+		defer db.Close()
+
+		title := "hijacked"
+		payload := models.UpdateTodoPayload{Title: &title}
+
+		mock.ExpectQuery("UPDATE todos SET title = \\$1 WHERE id = \\$2 AND owner_id = \\$3 RETURNING").
+			WithArgs(title, todoID, otherUserID).
+			WillReturnError(sql.ErrNoRows)
+
+		repo := NewTodoRepository(db)
+		if _, err := repo.Patch(todoID, otherUserID, payload); err != sql.ErrNoRows {
+			t.Fatalf("expected sql.ErrNoRows, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("Delete reports nothing deleted for another owner", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("failed to open sqlmock: %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectExec("DELETE FROM todos WHERE id = \\$1 AND owner_id = \\$2").
+			WithArgs(todoID, otherUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := NewTodoRepository(db)
+		deleted, err := repo.Delete(todoID, otherUserID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if deleted {
+			t.Fatal("expected Delete to report nothing deleted for another owner's todo")
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unmet expectations: %v", err)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"database/sql"
+
+	"todolist/models"
+)
+
+// UserRepository membungkus *sql.DB untuk query-query terkait tabel users.
+type UserRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository membuat UserRepository baru di atas koneksi db yang diberikan.
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// Create menyimpan user baru dan mengisi Id serta CreatedAt dari hasil RETURNING.
+func (r *UserRepository) Create(user *models.User) error {
+	return r.db.QueryRow(
+		"INSERT INTO users (email, password_hash) VALUES ($1, $2) RETURNING id, created_at",
+		user.Email, user.PasswordHash,
+	).Scan(&user.Id, &user.CreatedAt)
+}
+
+// GetByEmail mengambil satu user berdasarkan email. Mengembalikan sql.ErrNoRows jika tidak ditemukan.
+func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
+	var user models.User
+	err := r.db.QueryRow(
+		"SELECT id, email, password_hash, created_at FROM users WHERE email = $1", email,
+	).Scan(&user.Id, &user.Email, &user.PasswordHash, &user.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetByID mengambil satu user berdasarkan id. Mengembalikan sql.ErrNoRows jika tidak ditemukan.
+func (r *UserRepository) GetByID(id int) (*models.User, error) {
+	var user models.User
+	err := r.db.QueryRow(
+		"SELECT id, email, password_hash, created_at FROM users WHERE id = $1", id,
+	).Scan(&user.Id, &user.Email, &user.PasswordHash, &user.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
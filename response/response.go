@@ -0,0 +1,25 @@
+// Package response menyediakan satu bentuk envelope sukses yang dipakai
+// seluruh handler, sehingga client selalu menerima bentuk JSON yang sama
+// ({"status","data","meta","message"}) terlepas dari endpoint mana yang dipanggil.
+package response
+
+import "github.com/labstack/echo/v4"
+
+// envelope adalah bentuk JSON untuk response sukses. Meta opsional sehingga
+// endpoint yang tidak butuh pagination tidak perlu mengirim field kosong.
+type envelope struct {
+	Status  string `json:"status"`
+	Data    any    `json:"data,omitempty"`
+	Meta    any    `json:"meta,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Success mengirim response sukses tanpa meta, dipakai oleh sebagian besar endpoint.
+func Success(c echo.Context, status int, data any, message string) error {
+	return c.JSON(status, envelope{Status: "success", Data: data, Message: message})
+}
+
+// SuccessWithMeta mengirim response sukses beserta meta (mis. info pagination).
+func SuccessWithMeta(c echo.Context, status int, data any, meta any, message string) error {
+	return c.JSON(status, envelope{Status: "success", Data: data, Meta: meta, Message: message})
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"todolist/controllers"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterTodoRoutes mendaftarkan group /todos beserta handler-handlernya ke Echo.
+// authMiddleware wajib diterapkan di sini karena setiap handler todos mengasumsikan
+// user sudah diautentikasi dan mengambil owner id dari context.
+func RegisterTodoRoutes(e *echo.Echo, tc *controllers.TodoController, authMiddleware echo.MiddlewareFunc) {
+	todos := e.Group("/todos", authMiddleware)
+
+	todos.POST("", tc.Create)
+	todos.GET("", tc.List)
+	todos.GET("/:id", tc.GetByID)
+	todos.PATCH("/:id", tc.Update)
+	todos.DELETE("/:id", tc.Delete)
+}
+
+// RegisterAuthRoutes mendaftarkan /auth/register, /auth/login, /auth/refresh
+// (publik) dan /me (butuh authMiddleware).
+func RegisterAuthRoutes(e *echo.Echo, ac *controllers.AuthController, authMiddleware echo.MiddlewareFunc) {
+	authGroup := e.Group("/auth")
+	authGroup.POST("/register", ac.Register)
+	authGroup.POST("/login", ac.Login)
+	authGroup.POST("/refresh", ac.Refresh)
+
+	e.GET("/me", ac.Me, authMiddleware)
+}
+
+// RegisterHealthRoutes mendaftarkan /healthz dan /readyz untuk Kubernetes-style probes.
+func RegisterHealthRoutes(e *echo.Echo, hc *controllers.HealthController) {
+	e.GET("/healthz", hc.Healthz)
+	e.GET("/readyz", hc.Readyz)
+}
@@ -0,0 +1,48 @@
+// Package validation menghubungkan go-playground/validator ke Echo lewat
+// interface echo.Validator, dan menerjemahkan kegagalan validasi menjadi
+// apperror.AppError supaya ditangani oleh HTTPErrorHandler yang sama dengan
+// error lainnya.
+package validation
+
+import (
+	"errors"
+
+	"todolist/apperror"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Validator mengimplementasikan echo.Validator.
+type Validator struct {
+	validate *validator.Validate
+}
+
+// New membuat Validator baru siap dipasang sebagai e.Validator.
+func New() *Validator {
+	return &Validator{validate: validator.New()}
+}
+
+// Validate menjalankan validasi struct tag terhadap i. Kegagalan validasi
+// dikembalikan sebagai *apperror.AppError berstatus 422 dengan rincian per-field.
+func (v *Validator) Validate(i any) error {
+	if err := v.validate.Struct(i); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return apperror.Validation(fieldErrors(validationErrors))
+		}
+		return err
+	}
+	return nil
+}
+
+func fieldErrors(validationErrors validator.ValidationErrors) []apperror.FieldError {
+	details := make([]apperror.FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		details = append(details, apperror.FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: fe.Error(),
+		})
+	}
+	return details
+}